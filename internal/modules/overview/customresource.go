@@ -73,44 +73,83 @@ func customResourceDefinition(ctx context.Context, name string, o objectstore.Ob
 	return crd, nil
 }
 
+// crdSectionEntry is what crdSectionDescriber tracks per registered CRD: its
+// list describer, the GVK the index keys its objects under, and the cancel
+// func for the watch that keeps the index current for that GVK.
+type crdSectionEntry struct {
+	describer  describer.Describer
+	apiVersion string
+	kind       string
+	cancel     context.CancelFunc
+}
+
 type crdSectionDescriber struct {
-	describers map[string]describer.Describer
+	describers map[string]crdSectionEntry
 	path       string
 	title      string
+	index      objectstore.Indexer
 
 	mu sync.Mutex
 }
 
 var _ describer.Describer = (*crdSectionDescriber)(nil)
 
-func newCRDSectionDescriber(p, title string) *crdSectionDescriber {
+// newCRDSectionDescriber creates a section describer backed by index, which
+// it consults to skip describing (and hide) CRDs with no objects instead of
+// rendering each one and discarding empty results.
+func newCRDSectionDescriber(p, title string, index objectstore.Indexer) *crdSectionDescriber {
 	return &crdSectionDescriber{
-		describers: make(map[string]describer.Describer),
+		describers: make(map[string]crdSectionEntry),
 		path:       p,
 		title:      title,
+		index:      index,
 	}
 }
 
-func (csd *crdSectionDescriber) Add(name string, describer describer.Describer) {
+// Add registers d under name, replacing any entry already registered for
+// it. An informer resync re-fires watchCRDs' AddFunc for CRDs it has
+// already reported, so this can't assume name is new: the prior entry's
+// watch is canceled first, or its watchCRDInstances goroutine would leak.
+func (csd *crdSectionDescriber) Add(name string, d describer.Describer, apiVersion, kind string, cancel context.CancelFunc) {
 	csd.mu.Lock()
 	defer csd.mu.Unlock()
 
-	csd.describers[name] = describer
+	if existing, ok := csd.describers[name]; ok && existing.cancel != nil {
+		existing.cancel()
+	}
+
+	csd.describers[name] = crdSectionEntry{
+		describer:  d,
+		apiVersion: apiVersion,
+		kind:       kind,
+		cancel:     cancel,
+	}
 }
 
 func (csd *crdSectionDescriber) Remove(name string) {
 	csd.mu.Lock()
 	defer csd.mu.Unlock()
 
+	if entry, ok := csd.describers[name]; ok && entry.cancel != nil {
+		entry.cancel()
+	}
+
 	delete(csd.describers, name)
 }
 
 func (csd *crdSectionDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
 	csd.mu.Lock()
-	defer csd.mu.Unlock()
+	entries := make(map[string]crdSectionEntry, len(csd.describers))
+	for name, entry := range csd.describers {
+		entries[name] = entry
+	}
+	csd.mu.Unlock()
 
 	var names []string
-	for name := range csd.describers {
+	for name, entry := range entries {
+		if csd.index != nil && len(csd.index.ByGVK(entry.apiVersion, entry.kind)) == 0 {
+			continue
+		}
 		names = append(names, name)
 	}
 
@@ -119,7 +158,7 @@ func (csd *crdSectionDescriber) Describe(ctx context.Context, prefix, namespace
 	list := component.NewList("Custom Resources", nil)
 
 	for _, name := range names {
-		resp, err := csd.describers[name].Describe(ctx, prefix, namespace, options)
+		resp, err := entries[name].describer.Describe(ctx, prefix, namespace, options)
 		if err != nil {
 			return emptyContentResponse, err
 		}
@@ -161,15 +200,21 @@ type crdListDescriptionOption func(*crdListDescriber)
 type crdListDescriber struct {
 	name    string
 	path    string
+	version string
 	printer crdListPrinter
+	index   objectstore.Indexer
 }
 
 var _ describer.Describer = (*crdListDescriber)(nil)
 
-func newCRDListDescriber(name, path string, options ...crdListDescriptionOption) *crdListDescriber {
+// newCRDListDescriber creates a describer for a CRD's list view. version
+// pins the served version the list is read from; an empty version defers to
+// the CRD's storage version at Describe time.
+func newCRDListDescriber(name, version, path string, options ...crdListDescriptionOption) *crdListDescriber {
 	d := &crdListDescriber{
 		name:    name,
 		path:    path,
+		version: version,
 		printer: printer.CustomResourceListHandler,
 	}
 
@@ -180,6 +225,14 @@ func newCRDListDescriber(name, path string, options ...crdListDescriptionOption)
 	return d
 }
 
+// withCRDListIndex makes the list describer read its objects from index
+// instead of issuing a fresh objectstore.List call on every Describe.
+func withCRDListIndex(index objectstore.Indexer) crdListDescriptionOption {
+	return func(d *crdListDescriber) {
+		d.index = index
+	}
+}
+
 func (cld *crdListDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
 	objectStore := options.ObjectStore()
 	crd, err := customResourceDefinition(ctx, cld.name, objectStore)
@@ -187,12 +240,32 @@ func (cld *crdListDescriber) Describe(ctx context.Context, prefix, namespace str
 		return emptyContentResponse, err
 	}
 
-	objects, err := listCustomResources(ctx, crd, namespace, objectStore, options.LabelSet)
-	if err != nil {
-		return emptyContentResponse, err
+	version := cld.version
+	if version == "" {
+		version = crdStorageVersion(crd)
+	}
+
+	if !crdIsVersionServed(crd, version) {
+		return versionNotServedResponse(crd.Name, version), nil
 	}
 
-	table, err := cld.printer(cld.name, crd, objects, options.Link)
+	var objects []*unstructured.Unstructured
+	if cld.index != nil {
+		apiVersion, kind := schema.GroupVersionKind{
+			Group:   crd.Spec.Group,
+			Version: version,
+			Kind:    crd.Spec.Names.Kind,
+		}.ToAPIVersionAndKind()
+
+		objects = filterCustomResources(cld.index.ByGVK(apiVersion, kind), namespace, options.LabelSet)
+	} else {
+		objects, err = listCustomResources(ctx, crd, version, namespace, objectStore, options.LabelSet)
+		if err != nil {
+			return emptyContentResponse, err
+		}
+	}
+
+	table, err := cld.render(crd, objects, namespace, version, options)
 	if err != nil {
 		return emptyContentResponse, err
 	}
@@ -206,9 +279,63 @@ func (cld *crdListDescriber) Describe(ctx context.Context, prefix, namespace str
 	}, nil
 }
 
+// crdVersions returns the CRD's served/storage version set, synthesizing a
+// single entry from the deprecated spec.version field for CRDs that haven't
+// migrated to spec.versions.
+func crdVersions(crd *apiextv1beta1.CustomResourceDefinition) []apiextv1beta1.CustomResourceDefinitionVersion {
+	if len(crd.Spec.Versions) > 0 {
+		return crd.Spec.Versions
+	}
+
+	return []apiextv1beta1.CustomResourceDefinitionVersion{
+		{Name: crd.Spec.Version, Served: true, Storage: true},
+	}
+}
+
+// crdStorageVersion returns the CRD's storage version, used as the default
+// when a describer isn't pinned to a specific served version.
+func crdStorageVersion(crd *apiextv1beta1.CustomResourceDefinition) string {
+	for _, version := range crdVersions(crd) {
+		if version.Storage {
+			return version.Name
+		}
+	}
+
+	return crd.Spec.Version
+}
+
+// crdIsVersionServed reports whether version is currently served by the CRD.
+// A version can stop being served (but not yet removed) after a conversion
+// webhook upgrade, in which case callers should surface an error instead of
+// querying for a GVK the apiserver no longer recognizes.
+func crdIsVersionServed(crd *apiextv1beta1.CustomResourceDefinition, version string) bool {
+	for _, v := range crdVersions(crd) {
+		if v.Name == version {
+			return v.Served
+		}
+	}
+
+	return false
+}
+
+// versionNotServedResponse renders a clear error in place of a CRD's list or
+// object view when the version it's pinned to isn't served. Returning it
+// directly, rather than a Go error alongside emptyContentResponse, means the
+// UI shows the reason instead of a generic failure.
+func versionNotServedResponse(crdName, version string) component.ContentResponse {
+	message := fmt.Sprintf(
+		"version %q of %s is not served; it may have been removed by a conversion webhook upgrade", version, crdName)
+
+	return component.ContentResponse{
+		Title:      component.TitleFromString(fmt.Sprintf("%s: version not served", crdName)),
+		Components: []component.Component{component.NewText(message)},
+	}
+}
+
 func listCustomResources(
 	ctx context.Context,
 	crd *apiextv1beta1.CustomResourceDefinition,
+	version string,
 	namespace string,
 	o objectstore.ObjectStore,
 	selector *labels.Set) ([]*unstructured.Unstructured, error) {
@@ -217,7 +344,7 @@ func listCustomResources(
 	}
 	gvk := schema.GroupVersionKind{
 		Group:   crd.Spec.Group,
-		Version: crd.Spec.Version,
+		Version: version,
 		Kind:    crd.Spec.Names.Kind,
 	}
 
@@ -242,6 +369,37 @@ func listCustomResources(
 	return objects, nil
 }
 
+// filterCustomResources narrows an index's GVK bucket down to a namespace
+// and label selector, mirroring what listCustomResources asks the object
+// store to do server-side.
+func filterCustomResources(objects []*unstructured.Unstructured, namespace string, selector *labels.Set) []*unstructured.Unstructured {
+	var out []*unstructured.Unstructured
+	for _, object := range objects {
+		if namespace != "" && object.GetNamespace() != namespace {
+			continue
+		}
+
+		if selector != nil && !labels.SelectorFromSet(*selector).Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+
+		out = append(out, object)
+	}
+
+	return out
+}
+
+// render picks a table renderer for the CRD's list view. CRDs that declare
+// spec.additionalPrinterColumns get a table matching `kubectl get`; all
+// others fall back to the describer's generic printer.
+func (cld *crdListDescriber) render(crd *apiextv1beta1.CustomResourceDefinition, objects []*unstructured.Unstructured, namespace, version string, options describer.Options) (component.Component, error) {
+	if hasPrinterColumns(crd) {
+		return printerColumnTable(cld.name, version, crd, objects, options.Fields["extraColumns"] == "true")
+	}
+
+	return cld.printer(cld.name, crd, objects, options.Link)
+}
+
 func (cld *crdListDescriber) PathFilters() []describer.PathFilter {
 	return []describer.PathFilter{
 		*describer.NewPathFilter(cld.path, cld),
@@ -249,7 +407,7 @@ func (cld *crdListDescriber) PathFilters() []describer.PathFilter {
 }
 
 type crdPrinter func(ctx context.Context, crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured, options printer.Options) (component.Component, error)
-type resourceViewerPrinter func(ctx context.Context, object *unstructured.Unstructured, dashConfig config.Dash, q queryer.Queryer) (component.Component, error)
+type resourceViewerPrinter func(ctx context.Context, object *unstructured.Unstructured, dashConfig config.Dash, q queryer.Queryer, resolver *crdRelationshipResolver) (component.Component, error)
 type yamlPrinter func(runtime.Object) (*component.YAML, error)
 
 type crdDescriberOption func(*crdDescriber)
@@ -257,20 +415,28 @@ type crdDescriberOption func(*crdDescriber)
 type crdDescriber struct {
 	path                  string
 	name                  string
+	version               string
 	summaryPrinter        crdPrinter
 	resourceViewerPrinter resourceViewerPrinter
 	yamlPrinter           yamlPrinter
+	actions               *ActionDispatcher
+	relationships         *crdRelationshipResolver
 }
 
 var _ describer.Describer = (*crdDescriber)(nil)
 
-func newCRDDescriber(name, path string, options ...crdDescriberOption) *crdDescriber {
+// newCRDDescriber creates a describer for a single served version of a CRD's
+// object view. version is the default read when the request doesn't ask for
+// a different one via options.Fields["version"].
+func newCRDDescriber(name, version, path string, options ...crdDescriberOption) *crdDescriber {
 	d := &crdDescriber{
 		path:                  path,
 		name:                  name,
+		version:               version,
 		summaryPrinter:        printer.CustomResourceHandler,
 		resourceViewerPrinter: createCRDResourceViewer,
 		yamlPrinter:           yamlviewer.ToComponent,
+		actions:               NewActionDispatcher(),
 	}
 
 	for _, option := range options {
@@ -280,6 +446,23 @@ func newCRDDescriber(name, path string, options ...crdDescriberOption) *crdDescr
 	return d
 }
 
+// withCRDRelationships gives the describer's resource viewer a relationship
+// resolver so arbitrary CRDs get an owner/dependent/selector graph without
+// registering a custom queryer.
+func withCRDRelationships(resolver *crdRelationshipResolver) crdDescriberOption {
+	return func(d *crdDescriber) {
+		d.relationships = resolver
+	}
+}
+
+// withCRDActions replaces the describer's ActionDispatcher, letting plugins
+// register operations on the CRD's GVK ahead of time.
+func withCRDActions(actions *ActionDispatcher) crdDescriberOption {
+	return func(d *crdDescriber) {
+		d.actions = actions
+	}
+}
+
 func (cd *crdDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
 	objectStore := options.ObjectStore()
 	crd, err := customResourceDefinition(ctx, cd.name, objectStore)
@@ -287,9 +470,21 @@ func (cd *crdDescriber) Describe(ctx context.Context, prefix, namespace string,
 		return emptyContentResponse, err
 	}
 
+	version := options.Fields["version"]
+	if version == "" {
+		version = cd.version
+	}
+	if version == "" {
+		version = crdStorageVersion(crd)
+	}
+
+	if !crdIsVersionServed(crd, version) {
+		return versionNotServedResponse(crd.Name, version), nil
+	}
+
 	gvk := schema.GroupVersionKind{
 		Group:   crd.Spec.Group,
-		Version: crd.Spec.Version,
+		Version: version,
 		Kind:    crd.Spec.Names.Kind,
 	}
 
@@ -312,6 +507,12 @@ func (cd *crdDescriber) Describe(ctx context.Context, prefix, namespace string,
 		return emptyContentResponse, err
 	}
 
+	if actionName := options.Fields["action"]; actionName != "" {
+		if err := cd.actions.Dispatch(ctx, gvk, crd, actionName, objectStore, object, options.Fields); err != nil {
+			return emptyContentResponse, errors.Wrapf(err, "dispatching action %q", actionName)
+		}
+	}
+
 	title := component.Title(
 		options.Link.ForCustomResourceDefinition(cd.name, namespace),
 		component.NewText(object.GetName()))
@@ -328,15 +529,26 @@ func (cd *crdDescriber) Describe(ctx context.Context, prefix, namespace string,
 		Link:       linkGenerator,
 	}
 
-	summary, err := cd.summaryPrinter(ctx, crd, object, printOptions)
+	summaryPrinter := cd.summaryPrinter
+	if hasPrinterColumns(crd) {
+		summaryPrinter = printerColumnSummary
+	}
+
+	summary, err := summaryPrinter(ctx, crd, object, printOptions)
 	if err != nil {
 		return emptyContentResponse, err
 	}
 	summary.SetAccessor("summary")
 
+	if actions := cd.actions.Actions(gvk, crd, object); len(actions) > 0 {
+		if setter, ok := summary.(interface{ SetActions([]component.Action) }); ok {
+			setter.SetActions(actions)
+		}
+	}
+
 	cr.Add(summary)
 
-	resourceViewerComponent, err := cd.resourceViewerPrinter(ctx, object, options, options.Queryer)
+	resourceViewerComponent, err := cd.resourceViewerPrinter(ctx, object, options, options.Queryer, cd.relationships)
 	if err != nil {
 		return emptyContentResponse, err
 	}
@@ -352,6 +564,14 @@ func (cd *crdDescriber) Describe(ctx context.Context, prefix, namespace string,
 	yvComponent.SetAccessor("yaml")
 	cr.Add(yvComponent)
 
+	editComponent, err := cd.yamlPrinter(object)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	editComponent.SetAccessor("edit")
+	cr.Add(editComponent)
+
 	pluginPrinter := options.PluginManager()
 	tabs, err := pluginPrinter.Tabs(object)
 	if err != nil {
@@ -372,13 +592,29 @@ func (cd *crdDescriber) PathFilters() []describer.PathFilter {
 	}
 }
 
-func createCRDResourceViewer(ctx context.Context, object *unstructured.Unstructured, dashConfig config.Dash, q queryer.Queryer) (component.Component, error) {
+// createCRDResourceViewer renders the resource viewer graph for a custom
+// resource. When resolver is set, its owners, dependents, and
+// selector-matched objects are merged in alongside whatever the default
+// queryer already discovered, so CRDs like an OLM-style CSV or a workload CR
+// get a real graph without registering their own queryer.
+func createCRDResourceViewer(ctx context.Context, object *unstructured.Unstructured, dashConfig config.Dash, q queryer.Queryer, resolver *crdRelationshipResolver) (component.Component, error) {
 	rv, err := resourceviewer.New(dashConfig, resourceviewer.WithDefaultQueryer(q))
 	if err != nil {
 		return nil, err
 	}
 
-	return rv.Visit(ctx, object)
+	result, err := rv.Visit(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer, ok := result.(*component.ResourceViewer); ok {
+		if err := mergeRelated(viewer, object, resolver.Resolve(object)); err != nil {
+			return nil, errors.Wrap(err, "merging related objects into resource viewer")
+		}
+	}
+
+	return result, nil
 }
 
 type objectHandler func(ctx context.Context, object *unstructured.Unstructured)
@@ -415,39 +651,140 @@ func watchCRDs(ctx context.Context, o objectstore.ObjectStore, crdAddFunc, crdDe
 	}
 }
 
-func addCRD(ctx context.Context, name string, pm *describer.PathMatcher, sectionDescriber *crdSectionDescriber) {
+// crdFromUnstructured converts a CRD watch event's payload, matching the
+// conversion already used by customResourceDefinitionNames.
+func crdFromUnstructured(u *unstructured.Unstructured) (*apiextv1beta1.CustomResourceDefinition, error) {
+	crd := &apiextv1beta1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil, errors.Wrap(err, "crd conversion failed")
+	}
+
+	return crd, nil
+}
+
+// watchCRDInstances keeps index current for a CRD's storage-version GVK by
+// watching its instances, until ctx is canceled. It's started once per CRD
+// alongside watchCRDs, rather than each crdListDescriber issuing its own
+// List on every Describe.
+func watchCRDInstances(ctx context.Context, o objectstore.ObjectStore, crd *apiextv1beta1.CustomResourceDefinition, index objectstore.Indexer) {
+	if index == nil {
+		return
+	}
+
+	apiVersion, kind := schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: crdStorageVersion(crd),
+		Kind:    crd.Spec.Names.Kind,
+	}.ToAPIVersionAndKind()
+
+	handler := &kcache.ResourceEventHandlerFuncs{
+		AddFunc: func(object interface{}) {
+			if u, ok := object.(*unstructured.Unstructured); ok {
+				index.Add(u)
+			}
+		},
+		UpdateFunc: func(_, object interface{}) {
+			if u, ok := object.(*unstructured.Unstructured); ok {
+				index.Add(u)
+			}
+		},
+		DeleteFunc: func(object interface{}) {
+			if u, ok := object.(*unstructured.Unstructured); ok {
+				index.Delete(u)
+			}
+		},
+	}
+
+	key := objectstoreutil.Key{APIVersion: apiVersion, Kind: kind}
+
+	logger := log.From(ctx)
+	if err := o.Watch(ctx, key, handler); err != nil {
+		logger.Errorf("watching %s instances for CRD index: %v", kind, err)
+	}
+}
+
+// addCRD registers a CRD's list describer plus one object describer per
+// served version, so a deep link to e.g. /custom-resources/foo/v1beta1/bar
+// keeps working after foo is upgraded to also serve v1. It also starts the
+// watch that keeps sectionDescriber's index current for this CRD's objects.
+func addCRD(ctx context.Context, u *unstructured.Unstructured, o objectstore.ObjectStore, pm *describer.PathMatcher, sectionDescriber *crdSectionDescriber) {
 	logger := log.From(ctx)
-	logger.With("crd-name", name).Debugf("adding CRD")
 
-	cld := newCRDListDescriber(name, crdListPath(name))
+	crd, err := crdFromUnstructured(u)
+	if err != nil {
+		logger.Errorf("convert added CRD: %v", err)
+		return
+	}
+
+	name := crd.Name
+	logger = logger.With("crd-name", name)
+	logger.Debugf("adding CRD")
+
+	cld := newCRDListDescriber(name, "", crdListPath(name), withCRDListIndex(sectionDescriber.index))
+
+	apiVersion, kind := schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: crdStorageVersion(crd),
+		Kind:    crd.Spec.Names.Kind,
+	}.ToAPIVersionAndKind()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	sectionDescriber.Add(name, cld, apiVersion, kind, cancel)
 
-	sectionDescriber.Add(name, cld)
+	go watchCRDInstances(watchCtx, o, crd, sectionDescriber.index)
 
 	for _, pf := range cld.PathFilters() {
 		pm.Register(ctx, pf)
 	}
 
-	cd := newCRDDescriber(name, crdObjectPath(name))
-	for _, pf := range cd.PathFilters() {
-		pm.Register(ctx, pf)
+	for _, version := range crdVersions(crd) {
+		if !version.Served {
+			continue
+		}
+
+		cd := newCRDDescriber(name, version.Name, crdObjectPath(name, version.Name),
+			withCRDRelationships(newCRDRelationshipResolver(sectionDescriber.index)))
+		for _, pf := range cd.PathFilters() {
+			pm.Register(ctx, pf)
+		}
 	}
 }
 
-func deleteCRD(ctx context.Context, name string, pm *describer.PathMatcher, sectionDescriber *crdSectionDescriber) {
+// deleteCRD deregisters a CRD's list describer and every served-version
+// object describer that addCRD registered for it.
+func deleteCRD(ctx context.Context, u *unstructured.Unstructured, pm *describer.PathMatcher, sectionDescriber *crdSectionDescriber) {
 	logger := log.From(ctx)
-	logger.With("crd-name", name).Debugf("deleting CRD")
+
+	crd, err := crdFromUnstructured(u)
+	if err != nil {
+		logger.Errorf("convert deleted CRD: %v", err)
+		return
+	}
+
+	name := crd.Name
+	logger = logger.With("crd-name", name)
+	logger.Debugf("deleting CRD")
 
 	pm.Deregister(ctx, crdListPath(name))
-	pm.Deregister(ctx, crdObjectPath(name))
 
-	sectionDescriber.Remove(name)
+	for _, version := range crdVersions(crd) {
+		pm.Deregister(ctx, crdObjectPath(name, version.Name))
+	}
 
+	sectionDescriber.Remove(name)
 }
 
 func crdListPath(name string) string {
 	return path.Join("/custom-resources", name)
 }
 
-func crdObjectPath(name string) string {
-	return path.Join(crdListPath(name), describer.ResourceNameRegex)
-}
\ No newline at end of file
+func crdObjectPath(name, version string) string {
+	return path.Join(crdListPath(name), version, describer.ResourceNameRegex)
+}
+
+// crdObjectLinkPath is crdObjectPath with a literal resource name in place
+// of the path matcher's capture regex, for building a link to an actual
+// object rather than registering its route.
+func crdObjectLinkPath(namespace, name, version, resourceName string) string {
+	return path.Join("/content/overview/namespace", namespace, crdListPath(name), version, resourceName)
+}
@@ -0,0 +1,101 @@
+package overview
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/heptio/developer-dash/internal/objectstore"
+	"github.com/heptio/developer-dash/pkg/view/component"
+)
+
+// ActionFunc performs a named operation against a single custom resource.
+// fields is the request's describer.Options.Fields, carrying whatever the
+// frontend sent alongside the action name - the edited body for "edit", a
+// propagation policy for "delete", and so on.
+type ActionFunc func(ctx context.Context, o objectstore.ObjectStore, object *unstructured.Unstructured, fields map[string]string) error
+
+// CRDAction is a named operation offered as a button in a crdDescriber's
+// summary header. Available gates whether the action applies to a CRD's
+// objects at all, e.g. disabling "scale" on a CRD whose OpenAPI schema has
+// no spec.replicas field. A nil Available always offers the action.
+type CRDAction struct {
+	Name      string
+	Title     string
+	Available func(crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured) bool
+	Run       ActionFunc
+}
+
+// ActionDispatcher routes a named action request for a GVK to the CRDAction
+// a plugin registered for it, plus the built-in actions every CRD gets.
+type ActionDispatcher struct {
+	mu      sync.Mutex
+	actions map[schema.GroupVersionKind][]CRDAction
+}
+
+// NewActionDispatcher creates an empty ActionDispatcher. Plugins register
+// their own actions against it via Register; the built-in edit/delete
+// actions are always offered in addition to whatever's registered.
+func NewActionDispatcher() *ActionDispatcher {
+	return &ActionDispatcher{
+		actions: make(map[schema.GroupVersionKind][]CRDAction),
+	}
+}
+
+// Register adds a plugin-contributed action for gvk.
+func (ad *ActionDispatcher) Register(gvk schema.GroupVersionKind, action CRDAction) {
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+
+	ad.actions[gvk] = append(ad.actions[gvk], action)
+}
+
+// Dispatch runs the action named name registered for gvk against object. It
+// refuses to run an action Available reports as unavailable for crd, the
+// same check Actions applies when deciding which buttons to show.
+func (ad *ActionDispatcher) Dispatch(ctx context.Context, gvk schema.GroupVersionKind, crd *apiextv1beta1.CustomResourceDefinition, name string, o objectstore.ObjectStore, object *unstructured.Unstructured, fields map[string]string) error {
+	for _, action := range ad.actionsFor(gvk) {
+		if action.Name != name {
+			continue
+		}
+
+		if action.Available != nil && !action.Available(crd, object) {
+			return errors.Errorf("action %q is not available for this object", name)
+		}
+
+		return action.Run(ctx, o, object, fields)
+	}
+
+	return errors.Errorf("no action %q registered for %s", name, gvk)
+}
+
+// Actions returns the header buttons for object: the built-in actions plus
+// any registered for gvk, filtered by each action's Available check.
+func (ad *ActionDispatcher) Actions(gvk schema.GroupVersionKind, crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured) []component.Action {
+	var out []component.Action
+
+	for _, action := range ad.actionsFor(gvk) {
+		if action.Available != nil && !action.Available(crd, object) {
+			continue
+		}
+
+		out = append(out, component.Action{
+			Name:  action.Name,
+			Title: action.Title,
+		})
+	}
+
+	return out
+}
+
+func (ad *ActionDispatcher) actionsFor(gvk schema.GroupVersionKind) []CRDAction {
+	ad.mu.Lock()
+	registered := append([]CRDAction(nil), ad.actions[gvk]...)
+	ad.mu.Unlock()
+
+	return append(builtinCRDActions(), registered...)
+}
@@ -0,0 +1,201 @@
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+
+	"github.com/heptio/developer-dash/internal/objectstore"
+	"github.com/heptio/developer-dash/pkg/objectstoreutil"
+)
+
+// crdMutator is the write-capable subset of objectstore.ObjectStore the
+// built-in actions need. It's satisfied by the concrete object store this
+// package is otherwise handed as a read-only objectstore.ObjectStore; the
+// type assertion in requireMutator keeps that interface from having to grow
+// write methods just for this corner of the CRD describer.
+type crdMutator interface {
+	Update(ctx context.Context, key objectstoreutil.Key, updater func(*unstructured.Unstructured) error) error
+	Delete(ctx context.Context, key objectstoreutil.Key, propagationPolicy metav1.DeletionPropagation) error
+}
+
+func requireMutator(o objectstore.ObjectStore) (crdMutator, error) {
+	mutator, ok := o.(crdMutator)
+	if !ok {
+		return nil, errors.New("object store does not support mutating actions")
+	}
+
+	return mutator, nil
+}
+
+// builtinCRDActions are offered for every CRD regardless of plugin
+// registration.
+func builtinCRDActions() []CRDAction {
+	return []CRDAction{
+		editInPlaceAction(),
+		deleteWithPropagationAction(),
+		scaleAction(),
+	}
+}
+
+// lastAppliedConfigAnnotation mirrors kubectl apply's own annotation: when
+// present, it's the object as the user last saw it, giving the three-way
+// merge a baseline to diff the edit against instead of just diffing the
+// edit against whatever happens to be stored now.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// editInPlaceAction applies the user's edited body (fields["body"], a JSON
+// object sent by the "edit" tab) as a three-way JSON merge patch against
+// whatever is currently stored, the same strategy kubectl apply falls back
+// to for CRDs it has no typed schema for. The merge's "original" baseline is
+// the object's last-applied annotation when present, and otherwise the
+// object this CRDAction is handed, which crdDescriber reads in the same
+// request that dispatches the save. Fields the body leaves as they were in
+// that baseline are not touched. A change made to the stored object after the
+// user opened the editor is not protected: the baseline already includes it,
+// so the body's older value for that field is written back over it.
+func editInPlaceAction() CRDAction {
+	return CRDAction{
+		Name:  "edit",
+		Title: "Edit",
+		Run: func(ctx context.Context, o objectstore.ObjectStore, object *unstructured.Unstructured, fields map[string]string) error {
+			mutator, err := requireMutator(o)
+			if err != nil {
+				return err
+			}
+
+			modified := []byte(fields["body"])
+			if len(modified) == 0 {
+				return errors.New("edit action requires fields[\"body\"]")
+			}
+
+			original := []byte(object.GetAnnotations()[lastAppliedConfigAnnotation])
+			if len(original) == 0 {
+				original, err = json.Marshal(object.Object)
+				if err != nil {
+					return errors.Wrap(err, "marshaling original object")
+				}
+			}
+
+			key, err := objectstoreutil.KeyFromObject(object)
+			if err != nil {
+				return errors.Wrap(err, "building key for edited object")
+			}
+
+			return mutator.Update(ctx, key, func(stored *unstructured.Unstructured) error {
+				current, err := json.Marshal(stored.Object)
+				if err != nil {
+					return errors.Wrap(err, "marshaling stored object")
+				}
+
+				patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current)
+				if err != nil {
+					return errors.Wrap(err, "computing three-way merge patch")
+				}
+
+				merged, err := jsonpatch.MergePatch(current, patch)
+				if err != nil {
+					return errors.Wrap(err, "applying three-way merge patch")
+				}
+
+				return json.Unmarshal(merged, &stored.Object)
+			})
+		},
+	}
+}
+
+// deleteWithPropagationAction deletes the object using the propagation
+// policy selected in fields["propagationPolicy"] (Foreground, Background,
+// or Orphan), defaulting to Background when it's unset or unrecognized.
+func deleteWithPropagationAction() CRDAction {
+	return CRDAction{
+		Name:  "delete",
+		Title: "Delete",
+		Run: func(ctx context.Context, o objectstore.ObjectStore, object *unstructured.Unstructured, fields map[string]string) error {
+			mutator, err := requireMutator(o)
+			if err != nil {
+				return err
+			}
+
+			key, err := objectstoreutil.KeyFromObject(object)
+			if err != nil {
+				return errors.Wrap(err, "building key for deleted object")
+			}
+
+			return mutator.Delete(ctx, key, deletionPropagationPolicy(fields["propagationPolicy"]))
+		},
+	}
+}
+
+// deletionPropagationPolicy maps the frontend's propagation policy selector
+// to its metav1 value, defaulting to Background for an unset or
+// unrecognized selection.
+func deletionPropagationPolicy(selected string) metav1.DeletionPropagation {
+	switch metav1.DeletionPropagation(selected) {
+	case metav1.DeletePropagationForeground:
+		return metav1.DeletePropagationForeground
+	case metav1.DeletePropagationOrphan:
+		return metav1.DeletePropagationOrphan
+	default:
+		return metav1.DeletePropagationBackground
+	}
+}
+
+// scaleAction sets spec.replicas to fields["replicas"]. It's only available
+// for CRDs whose OpenAPI validation schema declares a spec.replicas field;
+// gating on the schema rather than the object itself means it's hidden for
+// every object of a CRD whose schema has no such field, not just for an
+// instance with spec.replicas unset. The CRD's Scale subresource setting is
+// not consulted.
+func scaleAction() CRDAction {
+	return CRDAction{
+		Name:  "scale",
+		Title: "Scale",
+		Available: func(crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured) bool {
+			return crdSchemaHasReplicas(crd)
+		},
+		Run: func(ctx context.Context, o objectstore.ObjectStore, object *unstructured.Unstructured, fields map[string]string) error {
+			replicas, err := strconv.ParseInt(fields["replicas"], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "parsing fields[\"replicas\"]")
+			}
+
+			mutator, err := requireMutator(o)
+			if err != nil {
+				return err
+			}
+
+			key, err := objectstoreutil.KeyFromObject(object)
+			if err != nil {
+				return errors.Wrap(err, "building key for scaled object")
+			}
+
+			return mutator.Update(ctx, key, func(stored *unstructured.Unstructured) error {
+				return unstructured.SetNestedField(stored.Object, replicas, "spec", "replicas")
+			})
+		},
+	}
+}
+
+// crdSchemaHasReplicas reports whether crd's OpenAPI validation schema
+// declares a spec.replicas field.
+func crdSchemaHasReplicas(crd *apiextv1beta1.CustomResourceDefinition) bool {
+	if crd == nil || crd.Spec.Validation == nil || crd.Spec.Validation.OpenAPIV3Schema == nil {
+		return false
+	}
+
+	spec, ok := crd.Spec.Validation.OpenAPIV3Schema.Properties["spec"]
+	if !ok {
+		return false
+	}
+
+	_, ok = spec.Properties["replicas"]
+	return ok
+}
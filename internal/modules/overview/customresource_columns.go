@@ -0,0 +1,145 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/heptio/developer-dash/internal/modules/overview/printer"
+	"github.com/heptio/developer-dash/pkg/view/component"
+)
+
+// jsonPathCache memoizes compiled jsonpath.JSONPath expressions keyed by CRD
+// name, resource version, and column path, so rendering a list of custom
+// resources doesn't recompile the same expression for every row.
+var jsonPathCache sync.Map // map[string]*jsonpath.JSONPath
+
+// hasPrinterColumns reports whether the CRD declares
+// spec.additionalPrinterColumns. When it doesn't, callers should fall back
+// to the generic table/summary handling.
+func hasPrinterColumns(crd *apiextv1beta1.CustomResourceDefinition) bool {
+	return len(crd.Spec.AdditionalPrinterColumns) > 0
+}
+
+// printerColumns returns the CRD's printer columns, omitting priority
+// columns (kubectl's "wide" columns) unless showExtra is set.
+func printerColumns(crd *apiextv1beta1.CustomResourceDefinition, showExtra bool) []apiextv1beta1.CustomResourceColumnDefinition {
+	var columns []apiextv1beta1.CustomResourceColumnDefinition
+	for _, column := range crd.Spec.AdditionalPrinterColumns {
+		if column.Priority > 0 && !showExtra {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func compiledJSONPath(crd *apiextv1beta1.CustomResourceDefinition, column apiextv1beta1.CustomResourceColumnDefinition) (*jsonpath.JSONPath, error) {
+	key := fmt.Sprintf("%s/%s/%s", crd.Name, crd.ResourceVersion, column.JSONPath)
+
+	if cached, ok := jsonPathCache.Load(key); ok {
+		return cached.(*jsonpath.JSONPath), nil
+	}
+
+	jp := jsonpath.New(column.Name)
+	jp.AllowMissingKeys(true)
+
+	template := column.JSONPath
+	if !strings.HasPrefix(template, "{") {
+		template = "{" + template + "}"
+	}
+
+	if err := jp.Parse(template); err != nil {
+		return nil, errors.Wrapf(err, "compiling printer column %q for %s", column.Name, crd.Name)
+	}
+
+	jsonPathCache.Store(key, jp)
+	return jp, nil
+}
+
+// evalPrinterColumn evaluates a single printer column's jsonPath against
+// object, returning "<none>" when the path has no match, matching
+// `kubectl get`'s behavior for missing fields.
+func evalPrinterColumn(crd *apiextv1beta1.CustomResourceDefinition, column apiextv1beta1.CustomResourceColumnDefinition, object *unstructured.Unstructured) (string, error) {
+	jp, err := compiledJSONPath(crd, column)
+	if err != nil {
+		return "", err
+	}
+
+	results, err := jp.FindResults(object.Object)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return "<none>", nil
+	}
+
+	values := make([]string, 0, len(results[0]))
+	for _, r := range results[0] {
+		values = append(values, fmt.Sprintf("%v", r.Interface()))
+	}
+
+	return strings.Join(values, ","), nil
+}
+
+// printerColumnTable renders a custom resource list table whose columns
+// match the CRD's spec.additionalPrinterColumns, the same columns `kubectl
+// get <cr>` shows.
+//
+// Rows link via crdObjectLinkPath rather than the generic link generator:
+// addCRD registers each served version's object describer under its own
+// path segment, and the generic ForObject predates that and doesn't know
+// to include it, so a row it links would 404.
+func printerColumnTable(crdName, version string, crd *apiextv1beta1.CustomResourceDefinition, objects []*unstructured.Unstructured, showExtra bool) (component.Component, error) {
+	columns := printerColumns(crd, showExtra)
+
+	cols := []component.TableCol{{Name: "Name", Accessor: "name"}}
+	for _, column := range columns {
+		cols = append(cols, component.TableCol{Name: column.Name, Accessor: column.Name})
+	}
+
+	table := component.NewTable(fmt.Sprintf("Custom Resources / %s", crdName), "There are no custom resources!", cols)
+
+	for _, object := range objects {
+		ref := crdObjectLinkPath(object.GetNamespace(), crdName, version, object.GetName())
+		nameLink := component.NewLink(object.GetName(), ref)
+
+		row := component.TableRow{"name": nameLink}
+
+		for _, column := range columns {
+			value, err := evalPrinterColumn(crd, column, object)
+			if err != nil {
+				return nil, err
+			}
+			row[column.Name] = component.NewText(value)
+		}
+
+		table.Add(row)
+	}
+
+	return table, nil
+}
+
+// printerColumnSummary renders a summary section from the CRD's printer
+// columns, used by crdDescriber in place of the generic summary handler so
+// an arbitrary CRD shows status/age/custom fields instead of nothing.
+func printerColumnSummary(ctx context.Context, crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured, options printer.Options) (component.Component, error) {
+	var sections []component.SummarySection
+
+	for _, column := range printerColumns(crd, true) {
+		value, err := evalPrinterColumn(crd, column, object)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, component.SummarySection{
+			Header:  column.Name,
+			Content: component.NewText(value),
+		})
+	}
+
+	return component.NewSummary("Status", sections...), nil
+}
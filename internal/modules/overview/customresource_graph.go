@@ -0,0 +1,169 @@
+package overview
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/heptio/developer-dash/internal/objectstore"
+	"github.com/heptio/developer-dash/pkg/view/component"
+)
+
+// crdRelationshipResolver discovers the objects related to a custom
+// resource without requiring its operator to register a custom
+// resourceviewer queryer: it walks metadata.ownerReferences upward, finds
+// objects whose ownerReferences point back to this one, and follows any
+// selector-shaped field under spec (spec.selector, spec.podSelector, and so
+// on). It's backed by the same objectstore.Indexer the CRD describers use,
+// so none of this issues a fresh List call.
+type crdRelationshipResolver struct {
+	index objectstore.Indexer
+}
+
+func newCRDRelationshipResolver(index objectstore.Indexer) *crdRelationshipResolver {
+	return &crdRelationshipResolver{index: index}
+}
+
+// Resolve returns the objects related to object, deduplicated by UID so an
+// object reachable by more than one path (e.g. an owner reference and a
+// selector) is only returned once.
+func (r *crdRelationshipResolver) Resolve(object *unstructured.Unstructured) map[types.UID]*unstructured.Unstructured {
+	related := make(map[types.UID]*unstructured.Unstructured)
+	if r == nil || r.index == nil {
+		return related
+	}
+
+	r.addOwners(object, related)
+	r.addDependents(object, related)
+	r.addSelectorMatches(object, related)
+
+	delete(related, object.GetUID())
+	return related
+}
+
+// addOwners walks metadata.ownerReferences upward. The index doesn't key
+// objects by their own UID, so each reference is resolved by scanning its
+// GVK's bucket for a matching UID.
+func (r *crdRelationshipResolver) addOwners(object *unstructured.Unstructured, related map[types.UID]*unstructured.Unstructured) {
+	for _, ref := range object.GetOwnerReferences() {
+		for _, candidate := range r.index.ByGVK(ref.APIVersion, ref.Kind) {
+			if candidate.GetUID() == ref.UID {
+				related[candidate.GetUID()] = candidate
+			}
+		}
+	}
+}
+
+// addDependents finds objects whose ownerReferences point back to object.
+func (r *crdRelationshipResolver) addDependents(object *unstructured.Unstructured, related map[types.UID]*unstructured.Unstructured) {
+	for _, dependent := range r.index.ByOwnerUID(object.GetUID()) {
+		related[dependent.GetUID()] = dependent
+	}
+}
+
+// addSelectorMatches follows any field under spec named "selector" or
+// ending in "Selector" (spec.selector, spec.podSelector, ...), matching
+// objects in the same namespace the way a workload CR selecting its pods, or
+// an OLM-style CSV selecting its owned APIs' instances, would.
+func (r *crdRelationshipResolver) addSelectorMatches(object *unstructured.Unstructured, related map[types.UID]*unstructured.Unstructured) {
+	spec, ok := object.Object["spec"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for key, value := range spec {
+		if key != "selector" && !strings.HasSuffix(key, "Selector") {
+			continue
+		}
+
+		selector, ok := parseSelectorField(value)
+		if !ok {
+			continue
+		}
+
+		for _, candidate := range r.index.ByLabelSelector(selector) {
+			if candidate.GetNamespace() == object.GetNamespace() {
+				related[candidate.GetUID()] = candidate
+			}
+		}
+	}
+}
+
+// parseSelectorField accepts either a bare map[string]string (a matchLabels
+// shorthand some CRDs use directly under spec.selector) or a
+// metav1.LabelSelector-shaped map with a matchLabels key.
+func parseSelectorField(value interface{}) (labels.Selector, bool) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	if matchLabels, ok := toStringMap(m); ok {
+		return labels.SelectorFromSet(matchLabels), true
+	}
+
+	if raw, ok := m["matchLabels"]; ok {
+		if matchLabels, ok := toStringMap(raw); ok {
+			return labels.SelectorFromSet(matchLabels), true
+		}
+	}
+
+	return nil, false
+}
+
+// mergeRelated adds object and each of related into rv as nodes, with an
+// edge from object to every related node. It builds the graph directly from
+// rv's node/edge primitives rather than handing related off to the
+// resourceviewer package, since nothing there knows how to interpret a
+// crdRelationshipResolver's results.
+func mergeRelated(rv *component.ResourceViewer, object *unstructured.Unstructured, related map[types.UID]*unstructured.Unstructured) error {
+	if rv == nil || len(related) == 0 {
+		return nil
+	}
+
+	selfID := nodeID(object)
+	rv.AddNode(selfID, resourceViewerNode(object))
+
+	for _, candidate := range related {
+		id := nodeID(candidate)
+		rv.AddNode(id, resourceViewerNode(candidate))
+
+		if err := rv.AddEdge(selfID, id, component.EdgeTypeExplicit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nodeID(object *unstructured.Unstructured) string {
+	return string(object.GetUID())
+}
+
+func resourceViewerNode(object *unstructured.Unstructured) component.Node {
+	return component.Node{
+		Name:       object.GetName(),
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+	}
+}
+
+func toStringMap(value interface{}) (map[string]string, bool) {
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		out[k] = s
+	}
+
+	return out, true
+}
@@ -0,0 +1,45 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func Test_parseSelectorField(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected labels.Selector
+		isOk     bool
+	}{
+		{
+			name:     "matchLabels shorthand",
+			value:    map[string]interface{}{"app": "foo"},
+			expected: labels.SelectorFromSet(labels.Set{"app": "foo"}),
+			isOk:     true,
+		},
+		{
+			name:     "label selector shape",
+			value:    map[string]interface{}{"matchLabels": map[string]interface{}{"app": "foo"}},
+			expected: labels.SelectorFromSet(labels.Set{"app": "foo"}),
+			isOk:     true,
+		},
+		{
+			name:  "not a map",
+			value: "nope",
+			isOk:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseSelectorField(tc.value)
+			assert.Equal(t, tc.isOk, ok)
+			if tc.isOk {
+				assert.Equal(t, tc.expected.String(), got.String())
+			}
+		})
+	}
+}
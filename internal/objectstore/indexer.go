@@ -0,0 +1,140 @@
+package objectstore
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	gvkIndexName      = "byGVK"
+	ownerUIDIndexName = "byOwnerUID"
+)
+
+// Indexer is a cache of unstructured objects kept current by a watch, so
+// callers that need "everything of this GVK" or "everything owned by this
+// UID" don't have to issue a List against the object store on every call.
+type Indexer interface {
+	// ByGVK returns the cached objects matching apiVersion and kind.
+	ByGVK(apiVersion, kind string) []*unstructured.Unstructured
+	// ByOwnerUID returns the cached objects whose ownerReferences include uid.
+	ByOwnerUID(uid types.UID) []*unstructured.Unstructured
+	// ByLabelSelector returns the cached objects matching selector.
+	ByLabelSelector(selector labels.Selector) []*unstructured.Unstructured
+	// Add inserts or updates object in the index.
+	Add(object *unstructured.Unstructured)
+	// Delete removes object from the index.
+	Delete(object *unstructured.Unstructured)
+}
+
+type cacheIndexer struct {
+	indexer cache.Indexer
+}
+
+// NewIndexer creates an Indexer backed by client-go's cache.Indexers, keyed
+// by GVK and by owner UID. Feed it from a watch via Add/Delete.
+func NewIndexer() Indexer {
+	indexers := cache.Indexers{
+		gvkIndexName:      indexByGVK,
+		ownerUIDIndexName: indexByOwnerUID,
+	}
+
+	return &cacheIndexer{
+		indexer: cache.NewIndexer(storeKeyFunc, indexers),
+	}
+}
+
+func gvkIndexKey(apiVersion, kind string) string {
+	return apiVersion + "/" + kind
+}
+
+// storeKeyFunc is the index's primary store key. cache.MetaNamespaceKeyFunc
+// (namespace/name only) isn't enough here: the index holds objects across
+// many GVKs, so two differently-kinded objects sharing a namespace/name
+// (e.g. a cluster-scoped Widget "foo" and a cluster-scoped Gadget "foo")
+// would otherwise collide and silently clobber each other.
+func storeKeyFunc(obj interface{}) (string, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return "", errors.Errorf("expected *unstructured.Unstructured, got %T", obj)
+	}
+
+	return gvkIndexKey(u.GetAPIVersion(), u.GetKind()) + "/" + u.GetNamespace() + "/" + u.GetName(), nil
+}
+
+func indexByGVK(obj interface{}) ([]string, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.Errorf("expected *unstructured.Unstructured, got %T", obj)
+	}
+
+	return []string{gvkIndexKey(u.GetAPIVersion(), u.GetKind())}, nil
+}
+
+func indexByOwnerUID(obj interface{}) ([]string, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.Errorf("expected *unstructured.Unstructured, got %T", obj)
+	}
+
+	var keys []string
+	for _, ref := range u.GetOwnerReferences() {
+		keys = append(keys, string(ref.UID))
+	}
+
+	return keys, nil
+}
+
+func (ci *cacheIndexer) Add(object *unstructured.Unstructured) {
+	_ = ci.indexer.Add(object)
+}
+
+func (ci *cacheIndexer) Delete(object *unstructured.Unstructured) {
+	_ = ci.indexer.Delete(object)
+}
+
+func (ci *cacheIndexer) ByGVK(apiVersion, kind string) []*unstructured.Unstructured {
+	items, err := ci.indexer.ByIndex(gvkIndexName, gvkIndexKey(apiVersion, kind))
+	if err != nil {
+		return nil
+	}
+
+	return toUnstructuredSlice(items)
+}
+
+func (ci *cacheIndexer) ByOwnerUID(uid types.UID) []*unstructured.Unstructured {
+	items, err := ci.indexer.ByIndex(ownerUIDIndexName, string(uid))
+	if err != nil {
+		return nil
+	}
+
+	return toUnstructuredSlice(items)
+}
+
+func (ci *cacheIndexer) ByLabelSelector(selector labels.Selector) []*unstructured.Unstructured {
+	var out []*unstructured.Unstructured
+	for _, item := range ci.indexer.List() {
+		u, ok := item.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		if selector.Matches(labels.Set(u.GetLabels())) {
+			out = append(out, u)
+		}
+	}
+
+	return out
+}
+
+func toUnstructuredSlice(items []interface{}) []*unstructured.Unstructured {
+	out := make([]*unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		if u, ok := item.(*unstructured.Unstructured); ok {
+			out = append(out, u)
+		}
+	}
+	return out
+}
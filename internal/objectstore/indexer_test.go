@@ -0,0 +1,91 @@
+package objectstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestObject(apiVersion, kind, namespace, name string, ownerUID types.UID, lbls map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetLabels(lbls)
+
+	if ownerUID != "" {
+		u.SetOwnerReferences([]metav1.OwnerReference{{UID: ownerUID}})
+	}
+
+	return u
+}
+
+func Test_Indexer_ByGVK(t *testing.T) {
+	index := NewIndexer()
+
+	one := newTestObject("example.com/v1", "Widget", "default", "one", "", nil)
+	two := newTestObject("example.com/v1", "Widget", "default", "two", "", nil)
+	other := newTestObject("example.com/v1", "Gadget", "default", "three", "", nil)
+
+	index.Add(one)
+	index.Add(two)
+	index.Add(other)
+
+	got := index.ByGVK("example.com/v1", "Widget")
+	assert.Len(t, got, 2)
+
+	index.Delete(one)
+	got = index.ByGVK("example.com/v1", "Widget")
+	assert.Len(t, got, 1)
+}
+
+func Test_Indexer_ByGVK_sameNamespaceName(t *testing.T) {
+	index := NewIndexer()
+
+	widget := newTestObject("example.com/v1", "Widget", "default", "foo", "", nil)
+	gadget := newTestObject("example.com/v1", "Gadget", "default", "foo", "", nil)
+
+	index.Add(widget)
+	index.Add(gadget)
+
+	gotWidgets := index.ByGVK("example.com/v1", "Widget")
+	require.Len(t, gotWidgets, 1)
+	assert.Equal(t, "Widget", gotWidgets[0].GetKind())
+
+	gotGadgets := index.ByGVK("example.com/v1", "Gadget")
+	require.Len(t, gotGadgets, 1)
+	assert.Equal(t, "Gadget", gotGadgets[0].GetKind())
+}
+
+func Test_Indexer_ByOwnerUID(t *testing.T) {
+	index := NewIndexer()
+
+	owned := newTestObject("example.com/v1", "Widget", "default", "child", types.UID("parent-uid"), nil)
+	unowned := newTestObject("example.com/v1", "Widget", "default", "other", "", nil)
+
+	index.Add(owned)
+	index.Add(unowned)
+
+	got := index.ByOwnerUID(types.UID("parent-uid"))
+	assert.Len(t, got, 1)
+	assert.Equal(t, "child", got[0].GetName())
+}
+
+func Test_Indexer_ByLabelSelector(t *testing.T) {
+	index := NewIndexer()
+
+	index.Add(newTestObject("example.com/v1", "Widget", "default", "a", "", map[string]string{"app": "foo"}))
+	index.Add(newTestObject("example.com/v1", "Widget", "default", "b", "", map[string]string{"app": "bar"}))
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "foo"})
+	got := index.ByLabelSelector(selector)
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, "a", got[0].GetName())
+}
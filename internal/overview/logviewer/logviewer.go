@@ -0,0 +1,139 @@
+// Package logviewer renders a component.Logs view for a pod and, when the
+// frontend subscribes to the stream it references, feeds that subscription
+// from a LogStreamer.
+package logviewer
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/heptio/developer-dash/internal/view/component"
+)
+
+// Option configures the StreamOptions a LogStreamer built for this view will
+// use.
+type Option func(*StreamOptions)
+
+// WithSinceSeconds restricts the stream to lines written in the last
+// seconds.
+func WithSinceSeconds(seconds int64) Option {
+	return func(o *StreamOptions) {
+		o.SinceSeconds = &seconds
+	}
+}
+
+// WithTailLines limits how many existing lines are replayed before the
+// stream starts following new output.
+func WithTailLines(lines int64) Option {
+	return func(o *StreamOptions) {
+		o.TailLines = &lines
+	}
+}
+
+// WithMatch only forwards lines matching re to subscribers.
+func WithMatch(re *regexp.Regexp) Option {
+	return func(o *StreamOptions) {
+		o.Match = re
+	}
+}
+
+// WithFollow overrides whether the stream tails new output after replaying
+// existing lines. ToComponent already defaults this to true for the live
+// view; it's exposed as an option so a caller building a StreamOptions for
+// something other than the live view (e.g. a one-shot log dump) can turn it
+// back off.
+func WithFollow(follow bool) Option {
+	return func(o *StreamOptions) {
+		o.Follow = follow
+	}
+}
+
+// ToComponent converts a pod in to a component for the logs view listing its
+// containers. The returned component's accessor is set to StreamRef, which
+// the frontend uses to open a subscription for live log lines; options are
+// resolved into a StreamOptions and registered under that same ref, for
+// whatever starts this pod's LogStreamer once the frontend subscribes to
+// retrieve via StreamOptionsFor.
+func ToComponent(object runtime.Object, options ...Option) (component.ViewComponent, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	pod, ok := object.(*corev1.Pod)
+	if !ok {
+		return nil, errors.Errorf("expected a pod, got %T", object)
+	}
+
+	var names []string
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+
+	so := StreamOptions{Follow: true}
+	for _, option := range options {
+		option(&so)
+	}
+
+	ref := StreamRef(pod.Namespace, pod.Name)
+	registerStreamOptions(ref, so)
+
+	logs := component.NewLogs(pod.Namespace, pod.Name, names)
+	logs.SetAccessor(ref)
+
+	return logs, nil
+}
+
+// StreamRef returns the identifier the frontend uses to open a subscription
+// for a pod's logs over the overview module's existing websocket/SSE
+// transport. A LogStreamer registered under the same ref serves the
+// subscription.
+func StreamRef(namespace, name string) string {
+	return path.Join("/content/overview/namespace", namespace, "pods", name, "logs", "stream")
+}
+
+// streamOptions holds the StreamOptions ToComponent resolved for each
+// StreamRef it's built a view for, so the transport that starts a
+// LogStreamer once the frontend subscribes can retrieve them without the
+// view and the streamer needing a direct reference to each other.
+var streamOptions sync.Map // map[string]StreamOptions
+
+func registerStreamOptions(ref string, so StreamOptions) {
+	streamOptions.Store(ref, so)
+}
+
+// deregisterStreamOptions removes ref's registration once Subscribe has
+// consumed it, so the map doesn't grow by one entry for every pod view ever
+// rendered over the dashboard's lifetime. A view rendered again after its
+// stream ends re-registers fresh options via ToComponent.
+func deregisterStreamOptions(ref string) {
+	streamOptions.Delete(ref)
+}
+
+// StreamOptionsFor returns the StreamOptions ToComponent registered for ref,
+// if a view has been built for it.
+func StreamOptionsFor(ref string) (StreamOptions, bool) {
+	v, ok := streamOptions.Load(ref)
+	if !ok {
+		return StreamOptions{}, false
+	}
+
+	return v.(StreamOptions), true
+}
+
+// parseStreamRef recovers the namespace and pod name StreamRef encoded into
+// ref, the inverse conversion Subscribe needs to build a LogStreamer.
+func parseStreamRef(ref string) (namespace, pod string, ok bool) {
+	parts := strings.Split(ref, "/")
+	// "", content, overview, namespace, <ns>, pods, <pod>, logs, stream
+	if len(parts) != 9 || parts[3] != "namespace" || parts[5] != "pods" {
+		return "", "", false
+	}
+
+	return parts[4], parts[6], true
+}
@@ -11,6 +11,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+func withAccessor(c component.ViewComponent, accessor string) component.ViewComponent {
+	c.SetAccessor(accessor)
+	return c
+}
+
 func Test_ToComponent(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -32,7 +37,7 @@ func Test_ToComponent(t *testing.T) {
 					},
 				},
 			},
-			expected: component.NewLogs("default", "pod", []string{"one", "two"}),
+			expected: withAccessor(component.NewLogs("default", "pod", []string{"one", "two"}), StreamRef("default", "pod")),
 		},
 		{
 			name:   "nil",
@@ -60,3 +65,28 @@ func Test_ToComponent(t *testing.T) {
 	}
 
 }
+
+func Test_ToComponent_registersStreamOptions(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+	}
+
+	_, err := ToComponent(pod, WithTailLines(50))
+	require.NoError(t, err)
+
+	so, ok := StreamOptionsFor(StreamRef("default", "pod"))
+	require.True(t, ok)
+	require.NotNil(t, so.TailLines)
+	assert.Equal(t, int64(50), *so.TailLines)
+	assert.True(t, so.Follow, "the live view should follow by default")
+}
+
+func Test_parseStreamRef(t *testing.T) {
+	namespace, pod, ok := parseStreamRef(StreamRef("default", "web"))
+	require.True(t, ok)
+	assert.Equal(t, "default", namespace)
+	assert.Equal(t, "web", pod)
+
+	_, _, ok = parseStreamRef("/not/a/stream/ref")
+	assert.False(t, ok)
+}
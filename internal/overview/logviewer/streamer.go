@@ -0,0 +1,272 @@
+package logviewer
+
+import (
+	"bufio"
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/heptio/developer-dash/internal/log"
+)
+
+// replayBufferSize bounds how many lines per container are retained so a
+// late subscriber can be caught up before live lines start arriving.
+const replayBufferSize = 1000
+
+// reconnectBackoffCap is the maximum delay between reconnect attempts for a
+// single container's log watch.
+const reconnectBackoffCap = 30 * time.Second
+
+// StreamOptions controls how each container's log watch is opened and
+// filtered before lines are forwarded to subscribers.
+type StreamOptions struct {
+	Follow       bool
+	SinceSeconds *int64
+	SinceTime    *metav1.Time
+	TailLines    *int64
+	Match        *regexp.Regexp
+}
+
+// Line is a single log line tagged with the container it came from and a
+// best-effort severity level for highlighting.
+type Line struct {
+	Container string
+	Text      string
+	Level     string
+}
+
+// containerBuffer is a fixed-size ring of the most recent lines seen for one
+// container.
+type containerBuffer struct {
+	mu   sync.Mutex
+	size int
+	buf  []Line
+}
+
+func newContainerBuffer(size int) *containerBuffer {
+	return &containerBuffer{size: size}
+}
+
+func (b *containerBuffer) add(l Line) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, l)
+	if len(b.buf) > b.size {
+		b.buf = b.buf[len(b.buf)-b.size:]
+	}
+}
+
+func (b *containerBuffer) snapshot() []Line {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make([]Line, len(b.buf))
+	copy(out, b.buf)
+	return out
+}
+
+// LogStreamer opens one Kubernetes pod log watch per container, multiplexes
+// the resulting lines onto a single channel tagged with their source
+// container, and keeps a bounded per-container replay buffer so a
+// subscriber that joins after the stream started still sees recent history.
+// A watch that is dropped by the apiserver is reconnected with backoff.
+type LogStreamer struct {
+	client    kubernetes.Interface
+	namespace string
+	pod       string
+	options   StreamOptions
+
+	mu      sync.Mutex
+	buffers map[string]*containerBuffer
+}
+
+// NewLogStreamer creates a LogStreamer for the named pod.
+func NewLogStreamer(client kubernetes.Interface, namespace, pod string, options StreamOptions) *LogStreamer {
+	return &LogStreamer{
+		client:    client,
+		namespace: namespace,
+		pod:       pod,
+		options:   options,
+		buffers:   make(map[string]*containerBuffer),
+	}
+}
+
+// Replay returns the buffered lines for a container so a subscriber can
+// catch up before live lines start arriving on its channel.
+func (ls *LogStreamer) Replay(container string) []Line {
+	return ls.bufferFor(container).snapshot()
+}
+
+// Stream opens a watch for each container and forwards lines to out until
+// ctx is canceled. It blocks until every container's watch has stopped.
+func (ls *LogStreamer) Stream(ctx context.Context, containers []string, out chan<- Line) error {
+	if len(containers) == 0 {
+		return errors.New("no containers to stream logs from")
+	}
+
+	var wg sync.WaitGroup
+	for _, container := range containers {
+		wg.Add(1)
+		go func(container string) {
+			defer wg.Done()
+			ls.streamContainer(ctx, container, out)
+		}(container)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// Subscribe is what the overview module's websocket/SSE transport calls
+// when a client subscribes to ref: it looks up the StreamOptions ToComponent
+// registered for ref, builds a new LogStreamer for it, then forwards lines
+// through send until ctx is canceled or the stream ends. Each call gets its
+// own LogStreamer, so the Replay step finds empty buffers and sends nothing:
+// no history is shared with an earlier or concurrent subscription, and the
+// only earlier lines a subscriber sees are those the log request itself
+// returns under TailLines, SinceSeconds or SinceTime.
+// It returns an error if ref was never registered (or Subscribe has already
+// consumed it).
+func Subscribe(ctx context.Context, client kubernetes.Interface, ref string, containers []string, send func(Line)) error {
+	so, ok := StreamOptionsFor(ref)
+	if !ok {
+		return errors.Errorf("no stream registered for %q", ref)
+	}
+	defer deregisterStreamOptions(ref)
+
+	namespace, pod, ok := parseStreamRef(ref)
+	if !ok {
+		return errors.Errorf("malformed stream ref %q", ref)
+	}
+
+	streamer := NewLogStreamer(client, namespace, pod, so)
+
+	for _, container := range containers {
+		for _, line := range streamer.Replay(container) {
+			send(line)
+		}
+	}
+
+	out := make(chan Line)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for line := range out {
+			send(line)
+		}
+	}()
+
+	err := streamer.Stream(ctx, containers, out)
+	close(out)
+	wg.Wait()
+
+	return err
+}
+
+func (ls *LogStreamer) bufferFor(container string) *containerBuffer {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	b, ok := ls.buffers[container]
+	if !ok {
+		b = newContainerBuffer(replayBufferSize)
+		ls.buffers[container] = b
+	}
+	return b
+}
+
+func (ls *LogStreamer) streamContainer(ctx context.Context, container string, out chan<- Line) {
+	logger := log.From(ctx).With("pod", ls.pod, "container", container)
+	backoff := time.Second
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if err := ls.readOnce(ctx, container, out); err != nil {
+			logger.Errorf("log stream disconnected, reconnecting: %v", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+			if backoff > reconnectBackoffCap {
+				backoff = reconnectBackoffCap
+			}
+			continue
+		}
+
+		if !ls.options.Follow {
+			return
+		}
+
+		backoff = time.Second
+	}
+}
+
+func (ls *LogStreamer) readOnce(ctx context.Context, container string, out chan<- Line) error {
+	opts := &corev1.PodLogOptions{
+		Container:    container,
+		Follow:       ls.options.Follow,
+		Timestamps:   true,
+		SinceSeconds: ls.options.SinceSeconds,
+		SinceTime:    ls.options.SinceTime,
+		TailLines:    ls.options.TailLines,
+	}
+
+	stream, err := ls.client.CoreV1().Pods(ls.namespace).GetLogs(ls.pod, opts).Stream()
+	if err != nil {
+		return errors.Wrapf(err, "opening log stream for container %q", container)
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		if ls.options.Match != nil && !ls.options.Match.MatchString(text) {
+			continue
+		}
+
+		line := Line{
+			Container: container,
+			Text:      text,
+			Level:     detectLevel(text),
+		}
+
+		ls.bufferFor(container).add(line)
+
+		select {
+		case out <- line:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
+
+var levelPattern = regexp.MustCompile(`(?i)\b(TRACE|DEBUG|INFO|WARN(?:ING)?|ERROR|FATAL|PANIC)\b`)
+
+// detectLevel makes a best-effort guess at a log line's severity so the
+// frontend can apply highlighting. Lines with no recognizable level return
+// "".
+func detectLevel(text string) string {
+	return strings.ToUpper(levelPattern.FindString(text))
+}
@@ -0,0 +1,39 @@
+package logviewer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_containerBuffer_bounded(t *testing.T) {
+	b := newContainerBuffer(3)
+
+	for i := 0; i < 5; i++ {
+		b.add(Line{Container: "one", Text: string(rune('a' + i))})
+	}
+
+	got := b.snapshot()
+	require := assert.New(t)
+	require.Len(got, 3)
+	require.Equal("c", got[0].Text)
+	require.Equal("e", got[2].Text)
+}
+
+func Test_detectLevel(t *testing.T) {
+	cases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "info", text: "2019-01-01T00:00:00Z INFO starting up", expected: "INFO"},
+		{name: "lowercase warn", text: "warn: retrying connection", expected: "WARN"},
+		{name: "no level", text: "just a plain line", expected: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, detectLevel(tc.text))
+		})
+	}
+}